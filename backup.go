@@ -0,0 +1,194 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	gapi "github.com/grafana/grafana-api-golang-client"
+	"log"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// snapshotFileName returns the on-disk file name used to back up a single dashboard
+// version, keyed by uid and version so multiple snapshots of the same dashboard can
+// coexist in the same backup directory.
+func snapshotFileName(uid string, version int64) string {
+	return fmt.Sprintf("%s.v%d.json", uid, version)
+}
+
+// BackupDashboard writes the given dashboard's JSON model to backupDir, keyed by the
+// dashboard's uid and version. It returns the path of the file it wrote.
+func BackupDashboard(dashboard *gapi.Dashboard, backupDir string) (string, error) {
+	if err := os.MkdirAll(backupDir, 0755); err != nil {
+		return "", fmt.Errorf("failed to create backup directory %s: %w", backupDir, err)
+	}
+
+	jsonBytes, err := json.MarshalIndent(dashboard.Model, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal dashboard model: %w", err)
+	}
+
+	uid, _ := dashboard.Model["uid"].(string)
+	versionFloat, _ := dashboard.Model["version"].(float64)
+	version := int64(versionFloat)
+	path := filepath.Join(backupDir, snapshotFileName(uid, version))
+
+	if err := os.WriteFile(path, jsonBytes, 0644); err != nil {
+		return "", fmt.Errorf("failed to write snapshot file %s: %w", path, err)
+	}
+
+	log.Printf("[INFO] Backed up dashboard %s (version %d) to %s", uid, version, path)
+	return path, nil
+}
+
+// latestSnapshotPath finds the most recently versioned snapshot file for uid in
+// backupDir. If no snapshot exists for uid, it returns an empty string.
+func latestSnapshotPath(backupDir, uid string) (string, error) {
+	matches, err := filepath.Glob(filepath.Join(backupDir, fmt.Sprintf("%s.v*.json", uid)))
+	if err != nil {
+		return "", err
+	}
+	if len(matches) == 0 {
+		return "", nil
+	}
+
+	// compare versions numerically: lexicographic order would rank v9 above v10.
+	latest, latestVersion := "", int64(-1)
+	for _, match := range matches {
+		var version int64
+		if _, err := fmt.Sscanf(strings.TrimPrefix(filepath.Base(match), uid+".v"), "%d.json", &version); err != nil {
+			continue
+		}
+		if version > latestVersion {
+			latest, latestVersion = match, version
+		}
+	}
+	return latest, nil
+}
+
+// RestoreDashboards reads the most recent backup snapshot for each of the given
+// dashboard uids from snapshotDir and pushes it back to Grafana via client.NewDashboard.
+// Unless force is true, a dashboard is skipped (and reported as a failed transaction)
+// when its current version in Grafana differs from the version recorded in the
+// snapshot, to avoid clobbering changes made since the backup was taken.
+func RestoreDashboards(client *gapi.Client, uids []string, snapshotDir string, force bool) ([]string, error) {
+	var failedTransactions []string
+
+	for _, uid := range uids {
+		snapshotPath, err := latestSnapshotPath(snapshotDir, uid)
+		if err != nil {
+			log.Printf("[ERROR] Failed to look up snapshot for dashboard %s: %v", uid, err)
+			failedTransactions = append(failedTransactions, uid)
+			continue
+		}
+		if snapshotPath == "" {
+			log.Printf("[ERROR] No snapshot found for dashboard %s in %s", uid, snapshotDir)
+			failedTransactions = append(failedTransactions, uid)
+			continue
+		}
+
+		snapshotBytes, err := os.ReadFile(snapshotPath)
+		if err != nil {
+			log.Printf("[ERROR] Failed to read snapshot file %s: %v", snapshotPath, err)
+			failedTransactions = append(failedTransactions, uid)
+			continue
+		}
+
+		var snapshotModel map[string]interface{}
+		if err := json.Unmarshal(snapshotBytes, &snapshotModel); err != nil {
+			log.Printf("[ERROR] Failed to unmarshal snapshot file %s: %v", snapshotPath, err)
+			failedTransactions = append(failedTransactions, uid)
+			continue
+		}
+
+		current, err := client.DashboardByUID(uid)
+		if err != nil {
+			log.Printf("[ERROR] Failed to get dashboard from Grafana: %v", err)
+			failedTransactions = append(failedTransactions, uid)
+			continue
+		}
+
+		if !force {
+			snapshotVersion, _ := snapshotModel["version"].(float64)
+			currentVersion, _ := current.Model["version"].(float64)
+			if int64(snapshotVersion) != int64(currentVersion) {
+				log.Printf("[ERROR] Dashboard %s has diverged since snapshot was taken (grafana version %d, snapshot version %d); use --force to overwrite anyway", uid, int64(currentVersion), int64(snapshotVersion))
+				failedTransactions = append(failedTransactions, uid)
+				continue
+			}
+		}
+
+		current.Model = snapshotModel
+		current.Overwrite = true
+		dashboardSaveResponse, err := client.NewDashboard(*current)
+		if err != nil {
+			log.Printf("[ERROR] Failed to restore dashboard %s in grafana: %v", uid, err)
+			failedTransactions = append(failedTransactions, uid)
+			continue
+		}
+
+		log.Printf("[INFO] Restored dashboard %s from %s: %v", uid, snapshotPath, dashboardSaveResponse)
+	}
+
+	return failedTransactions, nil
+}
+
+// snapshotUids returns the set of distinct dashboard uids that have at least one
+// snapshot file in backupDir.
+func snapshotUids(backupDir string) ([]string, error) {
+	matches, err := filepath.Glob(filepath.Join(backupDir, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[string]bool)
+	var uids []string
+	for _, match := range matches {
+		base := filepath.Base(match)
+		uid := base[:len(base)-len(filepath.Ext(base))]
+		if idx := strings.Index(uid, ".v"); idx != -1 {
+			uid = uid[:idx]
+		}
+		if !seen[uid] {
+			seen[uid] = true
+			uids = append(uids, uid)
+		}
+	}
+
+	return uids, nil
+}
+
+// commitBackup stages and commits everything under backupDir in the git repository
+// rooted there, and pushes to remote/branch if both are non-empty. It is a no-op if
+// backupDir has no .git entry of its own (a subdirectory of a repository is not
+// committed), and nothing is pushed when the commit step fails or has nothing to commit.
+func commitBackup(backupDir, remote, branch string) error {
+	if _, err := os.Stat(filepath.Join(backupDir, ".git")); err != nil {
+		return nil
+	}
+
+	addCmd := exec.Command("git", "-C", backupDir, "add", "-A")
+	if out, err := addCmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("git add failed: %w: %s", err, out)
+	}
+
+	commitCmd := exec.Command("git", "-C", backupDir, "commit", "-m", "dashboard snapshot update")
+	if out, err := commitCmd.CombinedOutput(); err != nil {
+		log.Printf("[INFO] Nothing to commit in backup repo %s: %s", backupDir, out)
+		return nil
+	}
+
+	if remote == "" || branch == "" {
+		return nil
+	}
+
+	pushCmd := exec.Command("git", "-C", backupDir, "push", remote, branch)
+	if out, err := pushCmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("git push failed: %w: %s", err, out)
+	}
+
+	log.Printf("[INFO] Pushed backup snapshot to %s/%s", remote, branch)
+	return nil
+}
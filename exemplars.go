@@ -0,0 +1,125 @@
+package main
+
+// DisableExemplars walks a parsed dashboard model and sets `exemplar: false` on every
+// Prometheus target, replacing the previous approach of string-replacing
+// `"exemplar":true` across the marshalled JSON. Walking the structure instead avoids
+// corrupting unrelated fields that happen to contain that substring, handles panels
+// nested inside row panels, and lets us skip datasources for which exemplars are
+// meaningless. It returns the number of panels and targets it changed.
+func DisableExemplars(model map[string]interface{}) (panelsChanged int, targetsChanged int, err error) {
+	panels, ok := model["panels"].([]interface{})
+	if !ok {
+		return 0, 0, nil
+	}
+
+	panelsChanged, targetsChanged = disableExemplarsInPanels(panels)
+	return panelsChanged, targetsChanged, nil
+}
+
+// disableExemplarsInPanels walks a panels array, recursing into the nested panels
+// array found on row panels, and disables exemplars on every target that belongs to
+// a Prometheus datasource.
+func disableExemplarsInPanels(panels []interface{}) (panelsChanged int, targetsChanged int) {
+	for _, p := range panels {
+		panel, ok := p.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		if nested, ok := panel["panels"].([]interface{}); ok {
+			nestedPanels, nestedTargets := disableExemplarsInPanels(nested)
+			panelsChanged += nestedPanels
+			targetsChanged += nestedTargets
+		}
+
+		targets, ok := panel["targets"].([]interface{})
+		if !ok {
+			continue
+		}
+
+		panelChanged := false
+		for _, t := range targets {
+			target, ok := t.(map[string]interface{})
+			if !ok {
+				continue
+			}
+
+			if !isPrometheusTarget(target) {
+				continue
+			}
+
+			if exemplar, ok := target["exemplar"]; ok && exemplar == true {
+				target["exemplar"] = false
+				targetsChanged++
+				panelChanged = true
+			}
+		}
+
+		if panelChanged {
+			panelsChanged++
+		}
+	}
+
+	return panelsChanged, targetsChanged
+}
+
+// ModelHasEnabledExemplars reports whether model contains at least one Prometheus target with
+// exemplars enabled, without mutating it. It walks the model the same way DisableExemplars does,
+// so a match here is a guarantee DisableExemplars will actually change something.
+func ModelHasEnabledExemplars(model map[string]interface{}) bool {
+	panels, ok := model["panels"].([]interface{})
+	if !ok {
+		return false
+	}
+	return panelsHaveEnabledExemplars(panels)
+}
+
+// panelsHaveEnabledExemplars is the read-only counterpart of disableExemplarsInPanels.
+func panelsHaveEnabledExemplars(panels []interface{}) bool {
+	for _, p := range panels {
+		panel, ok := p.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		if nested, ok := panel["panels"].([]interface{}); ok && panelsHaveEnabledExemplars(nested) {
+			return true
+		}
+
+		targets, ok := panel["targets"].([]interface{})
+		if !ok {
+			continue
+		}
+
+		for _, t := range targets {
+			target, ok := t.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			if !isPrometheusTarget(target) {
+				continue
+			}
+			if exemplar, ok := target["exemplar"]; ok && exemplar == true {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// isPrometheusTarget reports whether a panel target queries a Prometheus datasource,
+// for which exemplars are meaningful. A target is considered Prometheus either when
+// its datasource type is explicitly "prometheus", or when it has a PromQL `expr`
+// field and no explicit, non-Prometheus datasource of its own.
+func isPrometheusTarget(target map[string]interface{}) bool {
+	if ds, ok := target["datasource"].(map[string]interface{}); ok {
+		dsType, hasType := ds["type"].(string)
+		if hasType {
+			return dsType == "prometheus"
+		}
+	}
+
+	_, hasExpr := target["expr"]
+	return hasExpr
+}
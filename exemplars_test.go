@@ -0,0 +1,143 @@
+package main
+
+import "testing"
+
+func TestDisableExemplars(t *testing.T) {
+	model := map[string]interface{}{
+		"panels": []interface{}{
+			// Top-level Prometheus panel with exemplars enabled: should be disabled.
+			map[string]interface{}{
+				"id": 1,
+				"targets": []interface{}{
+					map[string]interface{}{
+						"expr":     "up",
+						"exemplar": true,
+					},
+				},
+			},
+			// Non-Prometheus target: exemplar must be left untouched.
+			map[string]interface{}{
+				"id": 2,
+				"targets": []interface{}{
+					map[string]interface{}{
+						"datasource": map[string]interface{}{"type": "loki"},
+						"expr":       `{job="foo"}`,
+						"exemplar":   true,
+					},
+				},
+			},
+			// Prometheus target with no exemplar key at all: nothing to change.
+			map[string]interface{}{
+				"id": 3,
+				"targets": []interface{}{
+					map[string]interface{}{
+						"expr": "up",
+					},
+				},
+			},
+			// Row panel with a nested Prometheus panel: the walk must recurse into it.
+			map[string]interface{}{
+				"id":   4,
+				"type": "row",
+				"panels": []interface{}{
+					map[string]interface{}{
+						"id": 5,
+						"targets": []interface{}{
+							map[string]interface{}{
+								"expr":     "rate(http_requests_total[5m])",
+								"exemplar": true,
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	panelsChanged, targetsChanged, err := DisableExemplars(model)
+	if err != nil {
+		t.Fatalf("DisableExemplars returned unexpected error: %v", err)
+	}
+	if panelsChanged != 2 {
+		t.Errorf("panelsChanged = %d, want 2", panelsChanged)
+	}
+	if targetsChanged != 2 {
+		t.Errorf("targetsChanged = %d, want 2", targetsChanged)
+	}
+
+	panels := model["panels"].([]interface{})
+
+	panel1 := panels[0].(map[string]interface{})
+	target1 := panel1["targets"].([]interface{})[0].(map[string]interface{})
+	if target1["exemplar"] != false {
+		t.Errorf("panel 1 target exemplar = %v, want false", target1["exemplar"])
+	}
+
+	panel2 := panels[1].(map[string]interface{})
+	target2 := panel2["targets"].([]interface{})[0].(map[string]interface{})
+	if target2["exemplar"] != true {
+		t.Errorf("non-Prometheus target exemplar = %v, want unchanged true", target2["exemplar"])
+	}
+
+	panel3 := panels[2].(map[string]interface{})
+	target3 := panel3["targets"].([]interface{})[0].(map[string]interface{})
+	if _, ok := target3["exemplar"]; ok {
+		t.Errorf("target with no exemplar key got one added: %v", target3["exemplar"])
+	}
+
+	rowPanel := panels[3].(map[string]interface{})
+	nestedPanel := rowPanel["panels"].([]interface{})[0].(map[string]interface{})
+	nestedTarget := nestedPanel["targets"].([]interface{})[0].(map[string]interface{})
+	if nestedTarget["exemplar"] != false {
+		t.Errorf("nested row panel target exemplar = %v, want false", nestedTarget["exemplar"])
+	}
+}
+
+func TestDisableExemplarsNoPanels(t *testing.T) {
+	model := map[string]interface{}{"title": "no panels field"}
+
+	panelsChanged, targetsChanged, err := DisableExemplars(model)
+	if err != nil {
+		t.Fatalf("DisableExemplars returned unexpected error: %v", err)
+	}
+	if panelsChanged != 0 || targetsChanged != 0 {
+		t.Errorf("got (%d, %d), want (0, 0) for a model with no panels", panelsChanged, targetsChanged)
+	}
+}
+
+func TestIsPrometheusTarget(t *testing.T) {
+	cases := []struct {
+		name   string
+		target map[string]interface{}
+		want   bool
+	}{
+		{
+			name:   "explicit prometheus datasource",
+			target: map[string]interface{}{"datasource": map[string]interface{}{"type": "prometheus"}},
+			want:   true,
+		},
+		{
+			name:   "explicit non-prometheus datasource",
+			target: map[string]interface{}{"datasource": map[string]interface{}{"type": "loki"}, "expr": "up"},
+			want:   false,
+		},
+		{
+			name:   "no datasource but has a promql expr",
+			target: map[string]interface{}{"expr": "up"},
+			want:   true,
+		},
+		{
+			name:   "no datasource and no expr",
+			target: map[string]interface{}{},
+			want:   false,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if got := isPrometheusTarget(c.target); got != c.want {
+				t.Errorf("isPrometheusTarget(%v) = %v, want %v", c.target, got, c.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,98 @@
+package main
+
+import (
+	"fmt"
+	gapi "github.com/grafana/grafana-api-golang-client"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// FilterOptions narrows the set of dashboards FindDashboardsMatchingMutators considers,
+// following the filter model used by gdg's dashboard commands: folder and tag
+// filters are pushed down into the Grafana search API to cut the number of
+// dashboards fetched, while the regex filters are applied afterwards against each
+// candidate's title.
+type FilterOptions struct {
+	Folder         string
+	Tags           []string
+	DashboardRegex string
+	ExcludeRegex   string
+}
+
+// tagList implements flag.Value so --tag can be repeated on the command line to
+// build up a list of tags.
+type tagList []string
+
+func (t *tagList) String() string {
+	return strings.Join(*t, ",")
+}
+
+func (t *tagList) Set(value string) error {
+	*t = append(*t, value)
+	return nil
+}
+
+// candidateDashboards returns the uids of dashboards matching opts,
+// narrowing the initial dashboard list via DashboardSearch's folderIds/tag params
+// when a folder or tag filter is given, then applying the dashboard/exclude regexes
+// against each candidate's title.
+func candidateDashboards(client *gapi.Client, opts FilterOptions) ([]string, error) {
+	var titles []string
+	var uids []string
+
+	if opts.Folder == "" && len(opts.Tags) == 0 {
+		dbSearchResponses, err := client.Dashboards()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get dashboard list from Grafana: %w", err)
+		}
+		for _, r := range dbSearchResponses {
+			uids = append(uids, r.UID)
+			titles = append(titles, r.Title)
+		}
+	} else {
+		params := url.Values{}
+		if opts.Folder != "" {
+			params.Set("folderIds", opts.Folder)
+		}
+		for _, tag := range opts.Tags {
+			params.Add("tag", tag)
+		}
+
+		results, err := DashboardSearch(client, params)
+		if err != nil {
+			return nil, fmt.Errorf("failed to search dashboards matching params %v: %w", params, err)
+		}
+		for _, r := range results {
+			uids = append(uids, r.UID)
+			titles = append(titles, r.Title)
+		}
+	}
+
+	var dashboardRe, excludeRe *regexp.Regexp
+	var err error
+	if opts.DashboardRegex != "" {
+		if dashboardRe, err = regexp.Compile(opts.DashboardRegex); err != nil {
+			return nil, fmt.Errorf("invalid --dashboard-regex %q: %w", opts.DashboardRegex, err)
+		}
+	}
+	if opts.ExcludeRegex != "" {
+		if excludeRe, err = regexp.Compile(opts.ExcludeRegex); err != nil {
+			return nil, fmt.Errorf("invalid --exclude-regex %q: %w", opts.ExcludeRegex, err)
+		}
+	}
+
+	var filtered []string
+	for i, uid := range uids {
+		title := titles[i]
+		if dashboardRe != nil && !dashboardRe.MatchString(title) {
+			continue
+		}
+		if excludeRe != nil && excludeRe.MatchString(title) {
+			continue
+		}
+		filtered = append(filtered, uid)
+	}
+
+	return filtered, nil
+}
@@ -9,128 +9,359 @@ import (
 	"log"
 	"net/url"
 	"os"
-	"regexp"
 	"strings"
+	"sync"
 )
 
 func main() {
-	// Setup args
-	exemplarDashboardFile := "testing"
-	baseURL := flag.String("url", "", "Base URL for grafana instance.")
-	apiKey := flag.String("api-token", "", "Grafana API token.")
-	flag.Parse()
+	// Subcommands: "disable-exemplars" (default, preserves the original CLI
+	// behavior) and "restore" for rolling back dashboards from a backup
+	// directory created along the way by disable-exemplars.
+	subcommand := "disable-exemplars"
+	args := os.Args[1:]
+	if len(args) > 0 && !strings.HasPrefix(args[0], "-") {
+		subcommand = args[0]
+		args = args[1:]
+	}
+
+	switch subcommand {
+	case "restore":
+		runRestore(args)
+	case "disable-exemplars":
+		runDisableExemplars(args)
+	default:
+		log.Fatalf("[ERROR] Unknown subcommand %q. Expected 'disable-exemplars' or 'restore'", subcommand)
+	}
+}
+
+// runDisableExemplars runs one or more Mutators (disable-exemplars by default, the tool's original
+// behavior) across matching dashboards, backing up each dashboard's pre-change model before it is
+// mutated.
+func runDisableExemplars(args []string) {
+	fs := flag.NewFlagSet("disable-exemplars", flag.ExitOnError)
+	baseURL := fs.String("url", "", "Base URL for grafana instance.")
+	apiKey := fs.String("api-token", "", "Grafana API token or service account token. Falls back to GRAFANA_TOKEN.")
+	username := fs.String("username", "", "Grafana username for Basic Auth. Falls back to GRAFANA_USER.")
+	password := fs.String("password", "", "Grafana password for Basic Auth. Falls back to GRAFANA_PASS.")
+	var orgIDs orgIDList
+	fs.Var(&orgIDs, "org-id", "Grafana org id to process. May be repeated. Defaults to the credentials' own org.")
+	allOrgs := fs.Bool("all-orgs", false, "Process every org on the Grafana instance, discovered via the orgs API. Overrides --org-id.")
+	backupDir := fs.String("backup-dir", "backups", "Directory (optionally a git repository) to write pre-change dashboard snapshots to.")
+	backupRemote := fs.String("backup-git-remote", "", "Git remote to push the backup directory to after each run, e.g. 'origin'.")
+	backupBranch := fs.String("backup-git-branch", "", "Git branch to push the backup directory to after each run.")
+	dryRun := fs.Bool("dry-run", false, "Find and compute exemplar changes without saving them back to Grafana; print a diff per dashboard instead.")
+	diffDir := fs.String("diff-dir", "", "Directory to write per-dashboard unified diffs to in --dry-run mode. If empty, diffs are printed to stdout.")
+	outputFormat := fs.String("output-format", "", "Set to 'json' to additionally write a machine-readable {uid, slug, folder, mutators_applied, error} report per dashboard.")
+	reportFile := fs.String("report-file", "", "File to write the --output-format=json report to. If empty, the report is printed to stdout.")
+	folder := fs.String("folder", "", "Only process dashboards in this folder id.")
+	dashboardRegex := fs.String("dashboard-regex", "", "Only process dashboards whose title matches this regex.")
+	excludeRegex := fs.String("exclude-regex", "", "Skip dashboards whose title matches this regex, even if --dashboard-regex also matches.")
+	var tags tagList
+	fs.Var(&tags, "tag", "Only process dashboards with this tag. May be repeated.")
+	concurrency := fs.Int("concurrency", 8, "Number of dashboards to process concurrently.")
+	rps := fs.Int("rps", 0, "Maximum number of requests per second to send to Grafana. 0 means unlimited.")
+	mutatorSpec := fs.String("mutator", "disable-exemplars", "Comma-separated list of mutators to run, e.g. 'disable-exemplars,rewrite-datasource=old_uid:new_uid'.")
+	fs.Parse(args)
 	if *baseURL == "" {
 		log.Fatalf("[ERROR] Failed to provide required flag 'url'")
 	}
-	if *apiKey == "" {
-		log.Fatalf("[ERROR] Failed to provide required flag 'api-token")
+
+	mutators, err := parseMutators(*mutatorSpec)
+	if err != nil {
+		log.Fatalf("[ERROR] Invalid --mutator: %v", err)
 	}
 
-	// Create client
-	config := gapi.Config{
-		APIKey:     *apiKey,
-		NumRetries: 3,
+	resolvedAPIKey, resolvedUsername, resolvedPassword := resolveCredentials(*apiKey, *username, *password)
+	baseConfig, err := baseClientConfig(resolvedAPIKey, resolvedUsername, resolvedPassword)
+	if err != nil {
+		log.Fatalf("[ERROR] %v", err)
 	}
-	client, err := gapi.New(*baseURL, config)
+
+	orgClient, err := gapi.New(*baseURL, baseConfig)
 	if err != nil {
 		log.Fatalf("[ERROR] Failed to create grafana API client: %v", err)
 	}
 
-	// Search grafana for dashboards with exemplars enabled
-	log.Println("[INFO] Searching for dashboards with exemplars")
-	matchedDashboardIds := FindDashboardsWithExemplars(client)
-	log.Printf("[INFO] Found %d dashboards with exemplars. Saving to file", len(matchedDashboardIds))
+	orgsToProcess, err := orgIDsToProcess(orgClient, resolvedAPIKey, *allOrgs, orgIDs)
+	if err != nil {
+		log.Fatalf("[ERROR] Failed to resolve orgs to process: %v", err)
+	}
+
+	filter := FilterOptions{Folder: *folder, Tags: tags, DashboardRegex: *dashboardRegex, ExcludeRegex: *excludeRegex}
+	conc := concurrencyOptions{concurrency: *concurrency, rps: *rps}
+	opts := processOptions{backupDir: *backupDir, dryRun: *dryRun, diffDir: *diffDir}
+
+	for _, orgID := range orgsToProcess {
+		client, err := clientForOrg(*baseURL, baseConfig, orgID)
+		if err != nil {
+			log.Printf("[ERROR] Failed to create grafana API client for org %d: %v", orgID, err)
+			continue
+		}
+
+		if orgID != 0 {
+			log.Printf("[INFO] Processing org %d", orgID)
+		}
+
+		mutateOrg(client, orgID, filter, conc, opts, mutators, *outputFormat, *reportFile, *backupRemote, *backupBranch)
+	}
+}
+
+// mutatedDashboardFile is the name of the file the matched-dashboard uid list and
+// failed-transaction list are written to, so finding dashboards and changing them
+// can be split into separate commands.
+const mutatedDashboardFile = "testing"
+
+// mutateOrg runs the full find-and-mutate pipeline against a single org's client,
+// applying every mutator in mutators to each matching dashboard.
+func mutateOrg(client *gapi.Client, orgID int64, filter FilterOptions, conc concurrencyOptions, opts processOptions, mutators []Mutator, outputFormat, reportFile, backupRemote, backupBranch string) {
+	dashboardFile := mutatedDashboardFile
+	if orgID != 0 {
+		dashboardFile = fmt.Sprintf("%s-org-%d", mutatedDashboardFile, orgID)
+	}
+
+	// Search grafana for dashboards matching one or more mutators
+	log.Println("[INFO] Searching for dashboards matching the requested mutators")
+	matchedDashboardIds := FindDashboardsMatchingMutators(client, filter, conc, mutators)
+	log.Printf("[INFO] Found %d matching dashboards. Saving to file", len(matchedDashboardIds))
 
 	// Save results to file to split out finding dashboards and changing dashboards into separate commands
-	err = writeLines(matchedDashboardIds, exemplarDashboardFile)
-	if err != nil {
+	if err := writeLines(matchedDashboardIds, dashboardFile); err != nil {
 		log.Printf("[ERROR] Failed to write file: %v", err)
 	}
 	log.Println("[INFO] Successfully wrote dashboard uids to file")
 
-	// read exemplar dashboard list
-	exemplarDashbordUids, err := readLines(exemplarDashboardFile)
+	// read matched dashboard list
+	matchedDashboardUids, err := readLines(dashboardFile)
 	if err != nil {
-		log.Fatalf("[ERROR] Failed to read file %s with error: %v", exemplarDashboardFile, err)
+		log.Fatalf("[ERROR] Failed to read file %s with error: %v", dashboardFile, err)
 	}
 
-	// Remove exemplars for dashboards and save
-	log.Printf("[INFO] Processing %d dashbords", len(exemplarDashbordUids))
-	failedTransactions, err := RemoveExemplarsFromDashboards(client, exemplarDashbordUids)
+	// Apply mutators to dashboards and save
+	log.Printf("[INFO] Processing %d dashbords", len(matchedDashboardUids))
+	reports, failedTransactions, err := ApplyMutatorsToDashboards(client, matchedDashboardUids, opts, conc, mutators)
 	if err != nil {
-		log.Fatalf("[ERROR] Encountered unrecoverable error when running RemoveExemplarsFromDashboards operation: %v", err)
+		log.Fatalf("[ERROR] Encountered unrecoverable error when running ApplyMutatorsToDashboards operation: %v", err)
+	}
+
+	if outputFormat == "json" {
+		if err := writeJSONReport(reports, reportFile); err != nil {
+			log.Printf("[ERROR] Failed to write dashboard report: %v", err)
+		}
+	}
+
+	if !opts.dryRun {
+		if err := commitBackup(opts.backupDir, backupRemote, backupBranch); err != nil {
+			log.Printf("[ERROR] Failed to commit backup directory %s to git: %v", opts.backupDir, err)
+		}
 	}
 
 	// Save failed transactions so easier to process in subsequent runs.
 	if failedTransactions != nil {
-		log.Printf("[INFO] Failed to remove exmplars from %d dashboards. Saving to file", len(failedTransactions))
-		err = writeLines(failedTransactions, fmt.Sprintf("%s-failed-transactions", exemplarDashboardFile))
+		log.Printf("[INFO] Failed to apply mutators to %d dashboards. Saving to file", len(failedTransactions))
+		if err := writeLines(failedTransactions, fmt.Sprintf("%s-failed-transactions", dashboardFile)); err != nil {
+			log.Printf("[ERROR] Failed to write file %s with error: %v", fmt.Sprintf("%s-failed-transactions", dashboardFile), err)
+		}
+	}
+
+	log.Printf("[INFO] Completed applying mutators to dashboards. %d dashboards succesfully processed with %d failures", len(matchedDashboardUids)-len(failedTransactions), len(failedTransactions))
+}
+
+// runRestore implements the "restore" subcommand: it rolls back the dashboards named
+// in --uid-file (or, if that's empty, every dashboard with a snapshot in
+// --backup-dir) to their most recent snapshot, refusing to overwrite dashboards that
+// have changed since the snapshot was taken unless --force is given.
+func runRestore(args []string) {
+	fs := flag.NewFlagSet("restore", flag.ExitOnError)
+	baseURL := fs.String("url", "", "Base URL for grafana instance.")
+	apiKey := fs.String("api-token", "", "Grafana API token or service account token. Falls back to GRAFANA_TOKEN.")
+	username := fs.String("username", "", "Grafana username for Basic Auth. Falls back to GRAFANA_USER.")
+	password := fs.String("password", "", "Grafana password for Basic Auth. Falls back to GRAFANA_PASS.")
+	orgID := fs.Int64("org-id", 0, "Grafana org id to restore into. Defaults to the credentials' own org.")
+	backupDir := fs.String("backup-dir", "backups", "Directory containing dashboard snapshots written by a previous mutate run.")
+	uidFile := fs.String("uid-file", "", "File containing newline-separated dashboard uids to restore. If empty, all snapshots in --backup-dir are restored.")
+	force := fs.Bool("force", false, "Overwrite dashboards even if their current version differs from the snapshot.")
+	fs.Parse(args)
+	if *baseURL == "" {
+		log.Fatalf("[ERROR] Failed to provide required flag 'url'")
+	}
+
+	resolvedAPIKey, resolvedUsername, resolvedPassword := resolveCredentials(*apiKey, *username, *password)
+	baseConfig, err := baseClientConfig(resolvedAPIKey, resolvedUsername, resolvedPassword)
+	if err != nil {
+		log.Fatalf("[ERROR] %v", err)
+	}
+
+	client, err := clientForOrg(*baseURL, baseConfig, *orgID)
+	if err != nil {
+		log.Fatalf("[ERROR] Failed to create grafana API client: %v", err)
+	}
+
+	var uids []string
+	if *uidFile != "" {
+		uids, err = readLines(*uidFile)
+		if err != nil {
+			log.Fatalf("[ERROR] Failed to read file %s with error: %v", *uidFile, err)
+		}
+	} else {
+		uids, err = snapshotUids(*backupDir)
 		if err != nil {
-			log.Printf("[ERROR] Failed to write file %s with error: %v", fmt.Sprintf("%s-failed-transactions", exemplarDashboardFile), err)
+			log.Fatalf("[ERROR] Failed to enumerate snapshots in %s: %v", *backupDir, err)
 		}
 	}
 
-	log.Printf("[INFO] Completed removing exemplars queries from dashboards. %d dashboards succesfully processed with %d failures", len(exemplarDashbordUids)-len(failedTransactions), len(failedTransactions))
+	log.Printf("[INFO] Restoring %d dashboards from %s", len(uids), *backupDir)
+	failedTransactions, err := RestoreDashboards(client, uids, *backupDir, *force)
+	if err != nil {
+		log.Fatalf("[ERROR] Encountered unrecoverable error when running RestoreDashboards operation: %v", err)
+	}
+
+	log.Printf("[INFO] Completed restoring dashboards. %d dashboards succesfully processed with %d failures", len(uids)-len(failedTransactions), len(failedTransactions))
+}
+
+// processOptions controls how ApplyMutatorsToDashboards processes each
+// dashboard: whether changes are actually saved back to Grafana, and where backups
+// and dry-run diffs are written.
+type processOptions struct {
+	backupDir string
+	dryRun    bool
+	diffDir   string
 }
 
-// RemoveExemplarsFromDashboards Given a grafana API client and a list of dashboard uids representing dashboards containing exemplar queries
-// this method disables the use of exemplars and updates the dashboard in grafana. If there are any dashboards
-// that fail to be updated, their uids will be returned as a slice of strings. This method will continue to process all dashboards unless
-// and until an unrecoverable error is encountered .
-func RemoveExemplarsFromDashboards(client *gapi.Client, exemplarDashboardUids []string) ([]string, error) {
+// concurrencyOptions controls how many dashboards are processed at once and how
+// fast requests are allowed to hit the Grafana API.
+type concurrencyOptions struct {
+	concurrency int
+	rps         int
+}
+
+// ApplyMutatorsToDashboards Given a grafana API client and a list of dashboard uids representing dashboards matching one
+// or more mutators, this method applies those mutators and, unless opts.dryRun is set, updates the dashboard in
+// grafana. It returns a DashboardReport per dashboard describing what changed (or would change), plus the uids of
+// any dashboards that failed to be updated. Dashboards are processed through a worker pool bounded by
+// conc.concurrency and conc.rps, so this continues to process every dashboard even if some fail; failures are
+// reported per dashboard and the returned error is always nil.
+func ApplyMutatorsToDashboards(client *gapi.Client, matchedDashboardUids []string, opts processOptions, conc concurrencyOptions, mutators []Mutator) ([]DashboardReport, []string, error) {
+	limiter := newRateLimiter(conc.rps)
+	sem := make(chan struct{}, conc.concurrency)
+	progress := &progressLogger{total: len(matchedDashboardUids)}
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	var reports []DashboardReport
 	var failedTransactions []string
 
-	for i, dashboardUid := range exemplarDashboardUids {
-		// tracking progress
-		if i%5 == 0 {
-			log.Printf("[INFO] Processed %d / %d dashboards", i, len(exemplarDashboardUids))
+	for _, dashboardUid := range matchedDashboardUids {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(dashboardUid string) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			defer progress.increment()
+
+			report, failed := processDashboard(client, dashboardUid, opts, limiter, mutators)
+
+			mu.Lock()
+			reports = append(reports, report)
+			if failed {
+				failedTransactions = append(failedTransactions, dashboardUid)
+			}
+			mu.Unlock()
+		}(dashboardUid)
+	}
+
+	wg.Wait()
+	return reports, failedTransactions, nil
+}
+
+// processDashboard fetches, backs up (unless dry-run), and applies every mutator in mutators to a
+// single dashboard, saving it back to Grafana unless opts.dryRun is set. It reports whether the
+// dashboard ended up in a failed state.
+func processDashboard(client *gapi.Client, dashboardUid string, opts processOptions, limiter *rateLimiter, mutators []Mutator) (DashboardReport, bool) {
+	report := DashboardReport{UID: dashboardUid}
+
+	limiter.wait()
+	var dashboard *gapi.Dashboard
+	err := withRetry(defaultRetryConfig, fmt.Sprintf("DashboardByUID(%s)", dashboardUid), func() error {
+		var err error
+		dashboard, err = client.DashboardByUID(dashboardUid)
+		return err
+	})
+	if err != nil {
+		log.Printf("[ERROR] Failed to get dashboard from Grafana: %v", err)
+		report.Error = err.Error()
+		return report, true
+	}
+
+	log.Printf("[INFO] Succesfully retrieved dashboard from Grafana: %v", dashboard.Meta.Slug)
+	report.Slug = dashboard.Meta.Slug
+	report.Folder = fmt.Sprintf("%v", dashboard.FolderID)
+
+	beforeJSON, err := json.MarshalIndent(dashboard.Model, "", "  ")
+	if err != nil {
+		log.Printf("[ERROR] Failed to marshal dashboard model: %v", err)
+		report.Error = err.Error()
+		return report, true
+	}
+
+	if !opts.dryRun {
+		if _, err := BackupDashboard(dashboard, opts.backupDir); err != nil {
+			log.Printf("[ERROR] Failed to back up dashboard %s before mutating it: %v", dashboardUid, err)
+			report.Error = err.Error()
+			return report, true
 		}
+	}
 
-		dashboard, err := client.DashboardByUID(dashboardUid)
-		if err != nil {
-			log.Printf("[ERROR] Failed to get dashboard from Grafana: %v", err)
-			failedTransactions = append(failedTransactions, dashboardUid)
+	for _, mutator := range mutators {
+		if !mutator.Match(dashboard.Model) {
 			continue
 		}
-
-		log.Printf("[INFO] Succesfully retrieved dashboard from Grafana: %v", dashboard.Meta.Slug)
-
-		// cast dashboard model to string.
-		jsonBytes, err := json.Marshal(dashboard.Model)
+		changed, err := mutator.Apply(dashboard.Model)
 		if err != nil {
-			log.Printf("[ERROR] Failed to get Marshal dashboard JSON: %v", err)
-			failedTransactions = append(failedTransactions, dashboardUid)
-			continue
+			log.Printf("[ERROR] Failed to apply mutator %s to dashboard model: %v", mutator.Name(), err)
+			report.Error = err.Error()
+			return report, true
+		}
+		if changed {
+			report.MutatorsApplied = append(report.MutatorsApplied, mutator.Name())
 		}
+	}
+	log.Printf("[INFO] Applied mutators %v to dashboard: %v", report.MutatorsApplied, dashboard.Meta.Slug)
 
-		// string replace exemplars: true => exemplars: false
-		exemplarMatcher := regexp.MustCompile(`"exemplar":true`)
-		processedModelString := exemplarMatcher.ReplaceAllString(string(jsonBytes), `"exemplar":false`)
+	if len(report.MutatorsApplied) == 0 {
+		log.Printf("[INFO] No mutator changed dashboard %v; skipping save", dashboard.Meta.Slug)
+		return report, false
+	}
 
-		// UnMarshall string back to JSON.
-		var processedModelJson map[string]interface{}
-		err = json.Unmarshal([]byte(processedModelString), &processedModelJson)
+	if opts.dryRun {
+		afterJSON, err := json.MarshalIndent(dashboard.Model, "", "  ")
 		if err != nil {
-			log.Printf("[ERROR] Failed to unmarshal processed dashboard model: %v", err)
-			failedTransactions = append(failedTransactions, dashboardUid)
-			continue
+			log.Printf("[ERROR] Failed to marshal processed dashboard model: %v", err)
+			report.Error = err.Error()
+			return report, true
 		}
-		log.Printf("[INFO] Sucessfully disabled exemplar queries from dashboadrd: %v", dashboard.Meta.Slug)
-
-		// Update dashboard object with new model and Save dashboard
-		dashboard.Model = processedModelJson
-		dashboard.Overwrite = true
-		dashboardSaveResponse, err := client.NewDashboard(*dashboard)
-		if err != nil {
-			log.Printf("[ERROR] Failed to update processed dashboard in grafana: %v", err)
-			failedTransactions = append(failedTransactions, dashboardUid)
-			continue
+		if err := writeDiffOutput(dashboardUid, beforeJSON, afterJSON, opts.diffDir); err != nil {
+			log.Printf("[ERROR] Failed to write diff for dashboard %s: %v", dashboardUid, err)
 		}
+		return report, false
+	}
 
-		log.Printf("[INFO] Dashboard save response from grafana: %v", dashboardSaveResponse)
+	// Save dashboard with the mutated model
+	dashboard.Overwrite = true
+	limiter.wait()
+	var dashboardSaveResponse interface{}
+	err = withRetry(defaultRetryConfig, fmt.Sprintf("NewDashboard(%s)", dashboardUid), func() error {
+		var err error
+		dashboardSaveResponse, err = client.NewDashboard(*dashboard)
+		return err
+	})
+	if err != nil {
+		log.Printf("[ERROR] Failed to update processed dashboard in grafana: %v", err)
+		report.Error = err.Error()
+		return report, true
 	}
 
-	return failedTransactions, nil
+	log.Printf("[INFO] Dashboard save response from grafana: %v", dashboardSaveResponse)
+	return report, false
 }
 
 // writeLines writes the lines to the given file.
@@ -165,48 +396,75 @@ func readLines(path string) ([]string, error) {
 	return lines, scanner.Err()
 }
 
-// FindDashboardsWithExemplars Given a grafana api client this method queries grafana for all dashboards and returns a list
-// of uids for dashboards with panels that contain exemplar queries.
-func FindDashboardsWithExemplars(client *gapi.Client) []string {
-	dbSearchResponses, err := client.Dashboards()
+// FindDashboardsMatchingMutators Given a grafana api client, a set of filters, and a list of mutators, this method
+// queries grafana for dashboards matching those filters and returns a list of uids for the ones that at least one
+// mutator reports as a Match. Dashboards are fetched through a worker pool bounded by conc.concurrency and
+// conc.rps so large instances don't have to be walked serially.
+func FindDashboardsMatchingMutators(client *gapi.Client, filter FilterOptions, conc concurrencyOptions, mutators []Mutator) []string {
+	candidateUids, err := candidateDashboards(client, filter)
 	if err != nil {
-		log.Fatalf("[ERROR] Failed to get dashbosards list from Grafana: %v", err)
+		log.Fatalf("[ERROR] Failed to get dashboard list from Grafana: %v", err)
 	}
 
-	log.Printf("[INFO] Retrived %d dashboards", len(dbSearchResponses))
-	var exemplarDashboardIds []string
-
-	for i, dbSearchResponse := range dbSearchResponses {
-		// trackking progress
-		if i%5 == 0 {
-			log.Printf("[INFO] Processed %d / %d dashboards", i, len(dbSearchResponses))
-		}
-
-		dashboard, err := client.DashboardByUID(dbSearchResponse.UID)
-		if err != nil {
-			log.Printf("[ERROR]Failed to get dashboard from Grafana: %v", err)
-		}
-
-		log.Printf("[INFO] Succesfully retrieved dashboard from Grafana: %v", dashboard.Meta.Slug)
-
-		jsonString, err := json.Marshal(dashboard.Model)
-		if err != nil {
-			log.Printf("[ERROR] Failed to get Marshal dashboard JSON: %v", err)
-		}
-
-		if strings.Contains(string(jsonString), `"exemplar":true`) {
-			log.Printf("[INFO] Found dashboard with exemplars: %v", dashboard.Meta.Slug)
-			exemplarDashboardIds = append(exemplarDashboardIds, dashboard.Model["uid"].(string))
-		}
+	log.Printf("[INFO] Retrived %d candidate dashboards", len(candidateUids))
+
+	limiter := newRateLimiter(conc.rps)
+	sem := make(chan struct{}, conc.concurrency)
+	progress := &progressLogger{total: len(candidateUids)}
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	var matchedDashboardIds []string
+
+	for _, uid := range candidateUids {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(uid string) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			defer progress.increment()
+
+			limiter.wait()
+			var dashboard *gapi.Dashboard
+			err := withRetry(defaultRetryConfig, fmt.Sprintf("DashboardByUID(%s)", uid), func() error {
+				var err error
+				dashboard, err = client.DashboardByUID(uid)
+				return err
+			})
+			if err != nil {
+				log.Printf("[ERROR]Failed to get dashboard from Grafana: %v", err)
+				return
+			}
+
+			log.Printf("[INFO] Succesfully retrieved dashboard from Grafana: %v", dashboard.Meta.Slug)
+
+			for _, mutator := range mutators {
+				if mutator.Match(dashboard.Model) {
+					log.Printf("[INFO] Found dashboard matching mutator %s: %v", mutator.Name(), dashboard.Meta.Slug)
+					mu.Lock()
+					matchedDashboardIds = append(matchedDashboardIds, dashboard.Model["uid"].(string))
+					mu.Unlock()
+					return
+				}
+			}
+		}(uid)
 	}
 
-	return exemplarDashboardIds
+	wg.Wait()
+	return matchedDashboardIds
+}
+
+// DashboardSearchResult is a single dashboard returned by DashboardSearch, trimmed down to
+// the fields callers have needed so far: the uid to act on and the title to filter by.
+type DashboardSearchResult struct {
+	UID   string
+	Title string
 }
 
 // DashboardSearch Given a set of url params (as specified by the Folder dashboard search API https://grafana.com/docs/grafana/latest/developers/http_api/folder_dashboard_search/
-// this method returns a list of dashboard UID's that match the params.
-func DashboardSearch(client *gapi.Client, params url.Values) ([]string, error) {
-	var dashboardUids []string
+// this method returns the dashboards that match the params.
+func DashboardSearch(client *gapi.Client, params url.Values) ([]DashboardSearchResult, error) {
+	var results []DashboardSearchResult
 
 	log.Printf("[INFO] Searching grafana for dashboards matching params: %v", params)
 	searchResponses, err := client.FolderDashboardSearch(params)
@@ -218,9 +476,9 @@ func DashboardSearch(client *gapi.Client, params url.Values) ([]string, error) {
 	log.Printf("[INFO] Found %d dashboard matching search query", len(searchResponses))
 
 	for _, resp := range searchResponses {
-		dashboardUids = append(dashboardUids, resp.UID)
+		results = append(results, DashboardSearchResult{UID: resp.UID, Title: resp.Title})
 		log.Printf("[INFO] Found dashboard matching params with uid: %s, title: %s", resp.UID, resp.Title)
 	}
 
-	return dashboardUids, nil
+	return results, nil
 }
@@ -0,0 +1,240 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// Mutator is a pluggable dashboard transformation. The find/fetch/save/retry pipeline in main.go is
+// driven by a list of Mutators rather than hard-coding exemplar-disabling, so new bulk-edit behaviors
+// can be added without duplicating that plumbing.
+type Mutator interface {
+	// Name identifies the mutator for --mutator selection and in reports.
+	Name() string
+	// Match reports whether model is a candidate for this mutator, so the driver can skip dashboards
+	// that don't need it.
+	Match(model map[string]interface{}) bool
+	// Apply mutates model in place and reports whether it changed anything.
+	Apply(model map[string]interface{}) (changed bool, err error)
+}
+
+// mutatorFactory builds a Mutator from the string after '=' in a --mutator spec, e.g. "old_uid:new_uid"
+// for rewrite-datasource. Mutators that take no parameter ignore it.
+type mutatorFactory func(param string) (Mutator, error)
+
+var mutatorRegistry = map[string]mutatorFactory{
+	"disable-exemplars":  func(string) (Mutator, error) { return &exemplarMutator{}, nil },
+	"strip-datasource":   func(string) (Mutator, error) { return &stripDatasourceMutator{}, nil },
+	"disable-refresh":    func(string) (Mutator, error) { return &disableRefreshMutator{}, nil },
+	"rewrite-datasource": newRewriteDatasourceMutator,
+}
+
+// parseMutators parses a --mutator spec such as "disable-exemplars,rewrite-datasource=old_uid:new_uid"
+// into the Mutators it names, in the order given.
+func parseMutators(spec string) ([]Mutator, error) {
+	var mutators []Mutator
+
+	for _, token := range strings.Split(spec, ",") {
+		token = strings.TrimSpace(token)
+		if token == "" {
+			continue
+		}
+
+		name, param, _ := strings.Cut(token, "=")
+		factory, ok := mutatorRegistry[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown mutator %q", name)
+		}
+
+		mutator, err := factory(param)
+		if err != nil {
+			return nil, fmt.Errorf("failed to build mutator %q: %w", name, err)
+		}
+		mutators = append(mutators, mutator)
+	}
+
+	if len(mutators) == 0 {
+		return nil, fmt.Errorf("no mutators specified")
+	}
+
+	return mutators, nil
+}
+
+// exemplarMutator is the tool's original behavior: disabling exemplars on every Prometheus target.
+type exemplarMutator struct{}
+
+func (m *exemplarMutator) Name() string { return "disable-exemplars" }
+
+func (m *exemplarMutator) Match(model map[string]interface{}) bool {
+	return ModelHasEnabledExemplars(model)
+}
+
+func (m *exemplarMutator) Apply(model map[string]interface{}) (bool, error) {
+	_, targetsChanged, err := DisableExemplars(model)
+	if err != nil {
+		return false, err
+	}
+	return targetsChanged > 0, nil
+}
+
+// stripDatasourceMutator removes the per-panel `datasource` override, falling back to the dashboard's
+// default datasource instead.
+type stripDatasourceMutator struct{}
+
+func (m *stripDatasourceMutator) Name() string { return "strip-datasource" }
+
+func (m *stripDatasourceMutator) Match(model map[string]interface{}) bool {
+	panels, ok := model["panels"].([]interface{})
+	if !ok {
+		return false
+	}
+	return panelsHaveDatasourceOverride(panels)
+}
+
+func (m *stripDatasourceMutator) Apply(model map[string]interface{}) (bool, error) {
+	panels, ok := model["panels"].([]interface{})
+	if !ok {
+		return false, nil
+	}
+	return stripDatasourceFromPanels(panels) > 0, nil
+}
+
+// panelsHaveDatasourceOverride is the read-only counterpart of stripDatasourceFromPanels, used by
+// Match to avoid reporting a dashboard as a candidate when there's nothing to actually strip.
+func panelsHaveDatasourceOverride(panels []interface{}) bool {
+	for _, p := range panels {
+		panel, ok := p.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		if nested, ok := panel["panels"].([]interface{}); ok && panelsHaveDatasourceOverride(nested) {
+			return true
+		}
+
+		if _, ok := panel["datasource"]; ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+func stripDatasourceFromPanels(panels []interface{}) int {
+	changed := 0
+
+	for _, p := range panels {
+		panel, ok := p.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		if nested, ok := panel["panels"].([]interface{}); ok {
+			changed += stripDatasourceFromPanels(nested)
+		}
+
+		if _, ok := panel["datasource"]; ok {
+			delete(panel, "datasource")
+			changed++
+		}
+	}
+
+	return changed
+}
+
+// disableRefreshMutator turns off a dashboard's auto-refresh interval.
+type disableRefreshMutator struct{}
+
+func (m *disableRefreshMutator) Name() string { return "disable-refresh" }
+
+func (m *disableRefreshMutator) Match(model map[string]interface{}) bool {
+	refresh, ok := model["refresh"]
+	return ok && refresh != false
+}
+
+func (m *disableRefreshMutator) Apply(model map[string]interface{}) (bool, error) {
+	if refresh, ok := model["refresh"]; ok && refresh != false {
+		model["refresh"] = false
+		return true, nil
+	}
+	return false, nil
+}
+
+// rewriteDatasourceMutator rewrites every panel/target datasource reference pointing at oldUID to
+// point at newUID instead, for use during Grafana datasource migrations.
+type rewriteDatasourceMutator struct {
+	oldUID string
+	newUID string
+}
+
+func newRewriteDatasourceMutator(param string) (Mutator, error) {
+	oldUID, newUID, ok := strings.Cut(param, ":")
+	if !ok || oldUID == "" || newUID == "" {
+		return nil, fmt.Errorf("rewrite-datasource requires a parameter of the form old_uid:new_uid, got %q", param)
+	}
+	return &rewriteDatasourceMutator{oldUID: oldUID, newUID: newUID}, nil
+}
+
+func (m *rewriteDatasourceMutator) Name() string {
+	return fmt.Sprintf("rewrite-datasource=%s:%s", m.oldUID, m.newUID)
+}
+
+func (m *rewriteDatasourceMutator) Match(model map[string]interface{}) bool {
+	jsonBytes, err := json.Marshal(model)
+	if err != nil {
+		return false
+	}
+	return strings.Contains(string(jsonBytes), fmt.Sprintf(`"uid":"%s"`, m.oldUID))
+}
+
+func (m *rewriteDatasourceMutator) Apply(model map[string]interface{}) (bool, error) {
+	panels, ok := model["panels"].([]interface{})
+	if !ok {
+		return false, nil
+	}
+	return m.rewriteInPanels(panels) > 0, nil
+}
+
+func (m *rewriteDatasourceMutator) rewriteInPanels(panels []interface{}) int {
+	changed := 0
+
+	for _, p := range panels {
+		panel, ok := p.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		if nested, ok := panel["panels"].([]interface{}); ok {
+			changed += m.rewriteInPanels(nested)
+		}
+
+		changed += m.rewriteDatasourceRef(panel["datasource"])
+
+		targets, ok := panel["targets"].([]interface{})
+		if !ok {
+			continue
+		}
+		for _, t := range targets {
+			target, ok := t.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			changed += m.rewriteDatasourceRef(target["datasource"])
+		}
+	}
+
+	return changed
+}
+
+func (m *rewriteDatasourceMutator) rewriteDatasourceRef(ds interface{}) int {
+	dsMap, ok := ds.(map[string]interface{})
+	if !ok {
+		return 0
+	}
+	if uid, ok := dsMap["uid"].(string); ok && uid == m.oldUID {
+		dsMap["uid"] = m.newUID
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,81 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/pmezard/go-difflib/difflib"
+	"log"
+	"os"
+	"path/filepath"
+)
+
+// DashboardReport is a machine-readable summary of what ApplyMutatorsToDashboards did
+// (or would do, in --dry-run mode) to a single dashboard. It's emitted by
+// --output-format=json so the tool can be wired into CI pipelines and PR checks
+// before an operator lets it mutate production Grafana.
+type DashboardReport struct {
+	UID             string   `json:"uid"`
+	Slug            string   `json:"slug"`
+	Folder          string   `json:"folder"`
+	MutatorsApplied []string `json:"mutators_applied,omitempty"`
+	Error           string   `json:"error,omitempty"`
+}
+
+// writeDiffOutput prints a unified diff of a dashboard's before/after JSON. If
+// diffDir is empty the diff is written to stdout; otherwise it's written to
+// <diffDir>/<uid>.diff.
+func writeDiffOutput(uid string, before, after []byte, diffDir string) error {
+	diff := difflib.UnifiedDiff{
+		A:        difflib.SplitLines(string(before)),
+		B:        difflib.SplitLines(string(after)),
+		FromFile: fmt.Sprintf("%s.before.json", uid),
+		ToFile:   fmt.Sprintf("%s.after.json", uid),
+		Context:  3,
+	}
+
+	text, err := difflib.GetUnifiedDiffString(diff)
+	if err != nil {
+		return fmt.Errorf("failed to compute diff for dashboard %s: %w", uid, err)
+	}
+	if text == "" {
+		return nil
+	}
+
+	if diffDir == "" {
+		fmt.Println(text)
+		return nil
+	}
+
+	if err := os.MkdirAll(diffDir, 0755); err != nil {
+		return fmt.Errorf("failed to create diff directory %s: %w", diffDir, err)
+	}
+
+	path := filepath.Join(diffDir, fmt.Sprintf("%s.diff", uid))
+	if err := os.WriteFile(path, []byte(text), 0644); err != nil {
+		return fmt.Errorf("failed to write diff file %s: %w", path, err)
+	}
+
+	log.Printf("[INFO] Wrote diff for dashboard %s to %s", uid, path)
+	return nil
+}
+
+// writeJSONReport marshals reports as a JSON array and writes them to path, or to
+// stdout if path is empty.
+func writeJSONReport(reports []DashboardReport, path string) error {
+	jsonBytes, err := json.MarshalIndent(reports, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal dashboard reports: %w", err)
+	}
+
+	if path == "" {
+		fmt.Println(string(jsonBytes))
+		return nil
+	}
+
+	if err := os.WriteFile(path, jsonBytes, 0644); err != nil {
+		return fmt.Errorf("failed to write report file %s: %w", path, err)
+	}
+
+	log.Printf("[INFO] Wrote dashboard report to %s", path)
+	return nil
+}
@@ -0,0 +1,106 @@
+package main
+
+import (
+	"errors"
+	"log"
+	"math/rand"
+	"net"
+	"regexp"
+	"sync/atomic"
+	"time"
+)
+
+// serverErrorPattern matches HTTP status codes worth retrying: 429 (rate limited)
+// and any 5xx (server error).
+var serverErrorPattern = regexp.MustCompile(`\b(429|5\d\d)\b`)
+
+// isRetryableError reports whether err looks like a transient failure worth
+// retrying: a network-level error, or an HTTP 429/5xx response surfaced via the
+// Grafana client's error message.
+func isRetryableError(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		return true
+	}
+
+	return serverErrorPattern.MatchString(err.Error())
+}
+
+// retryConfig controls the exponential backoff retry wrapper used around Grafana
+// API calls that can transiently fail with a 429/5xx or network error.
+type retryConfig struct {
+	maxAttempts int
+	baseDelay   time.Duration
+}
+
+// defaultRetryConfig matches the backoff used elsewhere in the Grafana tooling
+// ecosystem: a 500ms base delay, doubling each attempt, up to 5 attempts.
+var defaultRetryConfig = retryConfig{maxAttempts: 5, baseDelay: 500 * time.Millisecond}
+
+// withRetry calls fn, retrying with jittered exponential backoff (base delay,
+// factor 2) when fn returns a retryable error, up to cfg.maxAttempts attempts.
+// Non-retryable errors are returned immediately.
+func withRetry(cfg retryConfig, description string, fn func() error) error {
+	var err error
+	delay := cfg.baseDelay
+
+	for attempt := 1; attempt <= cfg.maxAttempts; attempt++ {
+		err = fn()
+		if err == nil {
+			return nil
+		}
+		if !isRetryableError(err) || attempt == cfg.maxAttempts {
+			return err
+		}
+
+		sleep := delay + time.Duration(rand.Int63n(int64(delay)+1))
+		log.Printf("[INFO] Retrying %s after transient error (attempt %d/%d, sleeping %s): %v", description, attempt, cfg.maxAttempts, sleep, err)
+		time.Sleep(sleep)
+		delay *= 2
+	}
+
+	return err
+}
+
+// rateLimiter is a simple token-bucket limiter used to cap the rate of requests
+// made against the Grafana API so a large, concurrent run stays polite to the
+// server. A nil *rateLimiter does not limit at all.
+type rateLimiter struct {
+	ticker *time.Ticker
+}
+
+// newRateLimiter returns a rateLimiter that allows at most rps requests per
+// second, or nil if rps is not positive (no limiting).
+func newRateLimiter(rps int) *rateLimiter {
+	if rps <= 0 {
+		return nil
+	}
+	return &rateLimiter{ticker: time.NewTicker(time.Second / time.Duration(rps))}
+}
+
+// wait blocks until the rate limiter allows another request through.
+func (r *rateLimiter) wait() {
+	if r == nil {
+		return
+	}
+	<-r.ticker.C
+}
+
+// progressLogger goroutine-safely logs "Processed N / total" progress messages
+// every 5 completed dashboards, matching the cadence of the original serial loops.
+type progressLogger struct {
+	total     int
+	completed int64
+}
+
+// increment records one more completed dashboard and logs progress if due.
+func (p *progressLogger) increment() {
+	n := atomic.AddInt64(&p.completed, 1)
+	if n%5 == 0 || int(n) == p.total {
+		log.Printf("[INFO] Processed %d / %d dashboards", n, p.total)
+	}
+}